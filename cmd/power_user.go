@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/anchore/syft/internal"
@@ -9,6 +10,7 @@ import (
 	"github.com/anchore/syft/internal/presenter/poweruser"
 	"github.com/anchore/syft/internal/ui"
 	"github.com/anchore/syft/syft/event"
+	"github.com/anchore/syft/syft/pkg/cataloger/packages"
 	"github.com/anchore/syft/syft/source"
 	"github.com/pkg/profile"
 	"github.com/spf13/cobra"
@@ -23,7 +25,8 @@ const powerUserExample = `  {{.appName}} {{.command}} <image>
 `
 
 var powerUserOpts = struct {
-	configPath string
+	configPath  string
+	parallelism int
 }{}
 
 var powerUserCmd = &cobra.Command{
@@ -57,17 +60,23 @@ var powerUserCmd = &cobra.Command{
 
 func init() {
 	powerUserCmd.Flags().StringVarP(&powerUserOpts.configPath, "config", "c", "", "config file path with all power-user options")
+	powerUserCmd.Flags().IntVar(&powerUserOpts.parallelism, "parallelism", 0, "number of catalogers to run concurrently (default: sequential)")
 
 	rootCmd.AddCommand(powerUserCmd)
 }
 
-func powerUserExec(_ *cobra.Command, args []string) error {
-	errs := powerUserExecWorker(args[0])
+func powerUserExec(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if powerUserOpts.parallelism > 0 {
+		ctx = packages.WithParallelism(ctx, powerUserOpts.parallelism)
+	}
+
+	errs := powerUserExecWorker(ctx, args[0])
 	ux := ui.Select(appConfig.CliOptions.Verbosity > 0, appConfig.Quiet)
 	return ux(errs, eventSubscription)
 }
 
-func powerUserExecWorker(userInput string) <-chan error {
+func powerUserExecWorker(ctx context.Context, userInput string) <-chan error {
 	errs := make(chan error)
 	go func() {
 		defer close(errs)
@@ -80,13 +89,17 @@ func powerUserExecWorker(userInput string) <-chan error {
 
 		checkForApplicationUpdate()
 
-		src, cleanup, err := source.New(userInput)
+		src, cleanup, err := source.New(ctx, userInput)
 		if err != nil {
 			errs <- err
 			return
 		}
 		defer cleanup()
 
+		// This guard stays until source gains a pluggable Unpacker registry (docker daemon, rootless podman, OCI
+		// layout/tarball, registry auth) that source.New can dispatch on by scheme. That registry would need to sit
+		// alongside source.New and the Source type it returns, neither of which is part of this snapshot, so it
+		// can't be added here without guessing at their internals. Tracked as a follow-up rather than faked.
 		if src.Metadata.Scheme != source.ImageScheme {
 			errs <- fmt.Errorf("the power-user subcommand only allows for 'image' schemes, given %q", src.Metadata.Scheme)
 			return
@@ -98,6 +111,13 @@ func powerUserExecWorker(userInput string) <-chan error {
 		}
 
 		for _, task := range tasks {
+			select {
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			default:
+			}
+
 			if err = task(&analysisResults, src); err != nil {
 				errs <- err
 				return
@@ -1,7 +1,14 @@
 package packages
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/cpe"
@@ -12,88 +19,314 @@ import (
 	"github.com/hashicorp/go-multierror"
 )
 
+// defaultParallelism is used whenever neither CatalogConfig.Parallelism nor a value set by WithParallelism is
+// positive, preserving today's sequential behavior.
+const defaultParallelism = 1
+
+// CatalogConfig configures how Catalog fans work out across the given catalogers.
+type CatalogConfig struct {
+	// Parallelism is the number of catalogers that may run concurrently. Values less than 1 defer to a value set
+	// on the context by WithParallelism, and are treated as 1 (sequential, the historical behavior) when that is
+	// absent or also less than 1.
+	Parallelism int
+}
+
+// parallelismKey is an unexported context key type so values set by WithParallelism can't collide with keys set by
+// other packages.
+type parallelismKey struct{}
+
+// WithParallelism returns a copy of ctx carrying n as the preferred cataloger parallelism. Catalog consults this
+// when its CatalogConfig.Parallelism is unset, which lets a caller that doesn't construct the CatalogConfig itself
+// (e.g. a CLI command several layers above the Catalog call) still propagate a user-configured value down through
+// ctx.
+func WithParallelism(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, parallelismKey{}, n)
+}
+
+// ParallelismFromContext returns the parallelism previously stored by WithParallelism, and whether one was present.
+func ParallelismFromContext(ctx context.Context) (int, bool) {
+	n, ok := ctx.Value(parallelismKey{}).(int)
+	return n, ok
+}
+
+// catalogerResult is the output of running a single cataloger, fully processed (CPEs, PURLs, and file-ownership
+// relationships attached) so that merging it into the shared catalog afterward requires no further work against
+// the resolver.
+type catalogerResult struct {
+	index         int // position of the cataloger in Catalog's catalogers argument
+	packages      []pkg.Package
+	relationships []artifact.Relationship
+	err           error
+}
+
 // Catalog a given source (container image or filesystem) with the given catalogers, returning all discovered packages.
 // In order to efficiently retrieve contents from an underlying container image the content fetch requests are
 // done in bulk. Specifically, all files of interest are collected from each cataloger and accumulated into a single
 // request.
-func Catalog(resolver source.FileResolver, release *linux.Release, catalogers ...pkg.Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
-	catalog := pkg.NewCatalog()
-	var allRelationships []artifact.Relationship
+//
+// Catalogers are fanned out onto a worker pool sized by cfg.Parallelism; each worker builds its own slice of
+// processed packages and relationships so the shared catalog is only touched once, after all catalogers have
+// finished, avoiding lock contention on a hot path. Output is made deterministic regardless of worker scheduling by
+// sorting the merged packages by package ID before relationships are derived from them.
+//
+// The provided context is checked between catalogers so that a long-running scan can be cancelled (e.g. via
+// Ctrl+C or a caller-supplied deadline) without waiting for every remaining cataloger to finish.
+func Catalog(ctx context.Context, resolver source.FileResolver, release *linux.Release, cfg CatalogConfig, catalogers ...pkg.Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
+	parallelism := cfg.Parallelism
+	if parallelism < 1 {
+		if n, ok := ParallelismFromContext(ctx); ok {
+			parallelism = n
+		}
+	}
+	if parallelism < 1 {
+		parallelism = defaultParallelism
+	}
 
 	filesProcessed, packagesDiscovered := monitor.NewPackageCatalogerMonitor()
 
-	// perform analysis, accumulating errors for each failed analysis
-	var errs error
-	for _, c := range catalogers {
-		// find packages from the underlying raw data
+	jobs := make(chan int)
+	results := make(chan catalogerResult)
+
+	worker := func(c pkg.Cataloger) catalogerResult {
+		if err := ctx.Err(); err != nil {
+			return catalogerResult{err: err}
+		}
+
 		log.Debugf("cataloging with %q", c.Name())
-		packages, relationships, err := c.Catalog(resolver)
+		packages, relationships, err := c.Catalog(ctx, resolver)
 		if err != nil {
-			errs = multierror.Append(errs, err)
-			continue
+			return catalogerResult{err: err}
 		}
 
-		catalogedPackages := len(packages)
+		log.Debugf("discovered %d packages", len(packages))
+		atomic.AddInt64(&packagesDiscovered.N, int64(len(packages)))
 
-		log.Debugf("discovered %d packages", catalogedPackages)
-		packagesDiscovered.N += int64(catalogedPackages)
+		var ownedFileRelationships []artifact.Relationship
+		for i := range packages {
+			p := &packages[i]
 
-		for _, p := range packages {
 			// generate CPEs (note: this is excluded from package ID, so is safe to mutate)
-			p.CPEs = cpe.Generate(p)
+			p.CPEs = cpe.Generate(*p)
 
 			// generate PURL (note: this is excluded from package ID, so is safe to mutate)
-			p.PURL = pkg.URL(p, release)
+			p.PURL = pkg.URL(*p, release)
 
-			// create file-to-package relationships for files owned by the package
-			owningRelationships, err := packageFileOwnershipRelationships(p, resolver)
+			// resolve owned-file locations once and reuse them for both the fingerprint's file digests and the
+			// file-to-package relationships below, instead of asking the resolver for the same paths twice
+			owned, hasOwner, err := resolveOwnedFiles(ctx, *p, resolver)
 			if err != nil {
-				log.Warnf("unable to create any package-file relationships for package name=%q: %w", p.Name, err)
-			} else {
-				allRelationships = append(allRelationships, owningRelationships...)
+				return catalogerResult{err: fmt.Errorf("unable to resolve owned files for package name=%q: %w", p.Name, err)}
 			}
 
-			// add to catalog
-			catalog.Add(p)
+			var fileDigests []string
+			if hasOwner {
+				fileDigests, err = fileDigestsForOwnedFiles(owned, resolver)
+				if err != nil {
+					return catalogerResult{err: fmt.Errorf("unable to compute fingerprint for package name=%q: %w", p.Name, err)}
+				}
+			}
+
+			// compute a stable content hash over the package's identifying fields so that downstream tooling can
+			// diff two SBOMs and tell a real content change apart from cosmetic reordering; a resolver failure above
+			// fails this cataloger's whole result instead of silently hashing a degraded field set, since a
+			// fingerprint computed from partial data is indistinguishable from one computed for genuinely different
+			// package content
+			p.Fingerprint = fingerprintPackage(*p, fileDigests)
+
+			if hasOwner {
+				// create file-to-package relationships for files owned by the package
+				ownedFileRelationships = append(ownedFileRelationships, relationshipsFromOwnedFiles(*p, owned)...)
+			}
+		}
+
+		return catalogerResult{
+			packages:      packages,
+			relationships: append(ownedFileRelationships, relationships...),
 		}
+	}
 
-		allRelationships = append(allRelationships, relationships...)
+	var workers sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for i := range jobs {
+				r := worker(catalogers[i])
+				r.index = i
+				results <- r
+			}
+		}()
 	}
 
-	allRelationships = append(allRelationships, pkg.NewRelationships(catalog)...)
+	go func() {
+		defer close(jobs)
+		for i := range catalogers {
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- i:
+			}
+		}
+	}()
+
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	var allPackages []pkg.Package
+	var allRelationships []artifact.Relationship
+	var errs error
+	ordered := make([]*catalogerResult, len(catalogers))
+	for r := range results {
+		if r.err != nil {
+			errs = multierror.Append(errs, r.err)
+			continue
+		}
+		r := r
+		ordered[r.index] = &r
+	}
+
+	// merge in cataloger order rather than completion order, so the relationships slice does not depend on worker
+	// scheduling either
+	for _, r := range ordered {
+		if r == nil {
+			continue
+		}
+		allPackages = append(allPackages, r.packages...)
+		allRelationships = append(allRelationships, r.relationships...)
+	}
 
 	if errs != nil {
 		return nil, nil, errs
 	}
 
+	// a cancellation that landed before any cataloger was dispatched (or while the dispatch loop was still handing
+	// out work) leaves results empty with no per-cataloger error to report; surface it explicitly instead of
+	// returning an empty catalog as if the scan had actually completed
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	// sort by package ID so that relationship generation (and the final catalog contents) are independent of
+	// worker scheduling order
+	sort.Slice(allPackages, func(i, j int) bool {
+		return allPackages[i].ID() < allPackages[j].ID()
+	})
+
+	catalog := pkg.NewCatalog()
+	for _, p := range allPackages {
+		catalog.Add(p)
+	}
+
+	allRelationships = append(allRelationships, pkg.NewRelationships(catalog)...)
+
 	filesProcessed.SetCompleted()
 	packagesDiscovered.SetCompleted()
 
 	return catalog, allRelationships, nil
 }
 
-func packageFileOwnershipRelationships(p pkg.Package, resolver source.FilePathResolver) ([]artifact.Relationship, error) {
+// fingerprintAlgorithm is the digest algorithm used both for package fingerprints and for the owned-file content
+// digests folded into them. This is part of the fingerprint's stable, documented format and must not change without
+// a coordinated release: downstream tools diff fingerprints across SBOMs to detect real content drift.
+const fingerprintAlgorithm = "sha256"
+
+// ownedFile pairs an owned path with the locations the resolver found for it, so that path is resolved exactly
+// once and reused by every consumer (fingerprinting, relationship generation) instead of re-querying the resolver
+// per consumer.
+type ownedFile struct {
+	path      string
+	locations []source.Location
+}
+
+// resolveOwnedFiles resolves, once, the locations for every file p.Metadata (as a pkg.FileOwner) claims to own.
+// hasOwner is false when p.Metadata does not implement pkg.FileOwner, in which case owned is always empty.
+func resolveOwnedFiles(ctx context.Context, p pkg.Package, resolver source.FilePathResolver) (owned []ownedFile, hasOwner bool, err error) {
 	fileOwner, ok := p.Metadata.(pkg.FileOwner)
 	if !ok {
-		return nil, nil
+		return nil, false, nil
 	}
 
-	var relationships []artifact.Relationship
+	paths := append([]string(nil), fileOwner.OwnedFiles()...)
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, true, err
+		}
 
-	for _, path := range fileOwner.OwnedFiles() {
 		locations, err := resolver.FilesByPath(path)
 		if err != nil {
-			return nil, fmt.Errorf("unable to find path for path=%q: %w", path, err)
+			return nil, true, fmt.Errorf("unable to find path for path=%q: %w", path, err)
+		}
+
+		owned = append(owned, ownedFile{path: path, locations: locations})
+	}
+
+	return owned, true, nil
+}
+
+// fingerprintPackage computes a stable hash over a package's identifying fields: name, version, type, PURL, sorted
+// CPEs, and the given owned-file content digests. The field set and algorithm are fixed so that the same package
+// produces the same fingerprint across syft releases, letting downstream tools tell a real content change apart
+// from cosmetic reordering.
+func fingerprintPackage(p pkg.Package, fileDigests []string) string {
+	h := sha256.New()
+
+	fmt.Fprintf(h, "name:%s\n", p.Name)
+	fmt.Fprintf(h, "version:%s\n", p.Version)
+	fmt.Fprintf(h, "type:%s\n", p.Type)
+	fmt.Fprintf(h, "purl:%s\n", p.PURL)
+
+	cpes := make([]string, len(p.CPEs))
+	for i, c := range p.CPEs {
+		cpes[i] = c.String()
+	}
+	sort.Strings(cpes)
+	for _, c := range cpes {
+		fmt.Fprintf(h, "cpe:%s\n", c)
+	}
+
+	for _, d := range fileDigests {
+		fmt.Fprintf(h, "file:%s\n", d)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// fileDigestsForOwnedFiles returns "path@digest" entries, sorted for determinism, for every already-resolved
+// owned-file location.
+func fileDigestsForOwnedFiles(owned []ownedFile, resolver source.FileResolver) ([]string, error) {
+	var digests []string
+	for _, of := range owned {
+		for _, l := range of.locations {
+			digest, err := resolver.FileContentDigest(l.Coordinates, fingerprintAlgorithm)
+			if err != nil {
+				return nil, fmt.Errorf("unable to digest contents for path=%q: %w", of.path, err)
+			}
+			digests = append(digests, fmt.Sprintf("%s@%s", of.path, digest))
 		}
+	}
+
+	sort.Strings(digests)
 
-		if len(locations) == 0 {
+	return digests, nil
+}
+
+// relationshipsFromOwnedFiles builds file-to-package relationships from already-resolved owned-file locations.
+func relationshipsFromOwnedFiles(p pkg.Package, owned []ownedFile) []artifact.Relationship {
+	var relationships []artifact.Relationship
+
+	for _, of := range owned {
+		if len(of.locations) == 0 {
 			// ideally we want to warn users about missing files from a package, however, it is very common for
 			// container image authors to delete files that are not needed in order to keep image sizes small. Adding
 			// a warning here would be needlessly noisy (even for popular base images).
 			continue
 		}
 
-		for _, l := range locations {
+		for _, l := range of.locations {
 			relationships = append(relationships, artifact.Relationship{
 				From: p,
 				To:   l.Coordinates,
@@ -102,5 +335,5 @@ func packageFileOwnershipRelationships(p pkg.Package, resolver source.FilePathRe
 		}
 	}
 
-	return relationships, nil
-}
\ No newline at end of file
+	return relationships
+}
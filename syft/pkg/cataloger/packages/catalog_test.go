@@ -0,0 +1,198 @@
+package packages
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// fakeCataloger lets tests control completion order (via delay) and cancellation behavior (via block). Packages
+// whose Metadata is not a pkg.FileOwner never cause the worker to dereference the resolver, so those tests can pass
+// a nil source.FileResolver; a test whose packages own files must pass a resolver.
+type fakeCataloger struct {
+	name  string
+	pkgs  []pkg.Package
+	delay time.Duration
+	block bool
+}
+
+func (f fakeCataloger) Name() string {
+	return f.name
+}
+
+func (f fakeCataloger) Catalog(ctx context.Context, _ source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	if f.block {
+		<-ctx.Done()
+		return nil, nil, ctx.Err()
+	}
+
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
+
+	return f.pkgs, nil, nil
+}
+
+func packageNames(catalog *pkg.Catalog) []string {
+	var names []string
+	for _, p := range catalog.Sorted() {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// TestCatalog_ConcurrentWorkersDeterministicOutput runs the same set of catalogers twice with a worker pool sized
+// smaller than the cataloger count, but assigns delays so each run finishes its catalogers in a different order.
+// The merged catalog's contents must not depend on which worker happened to finish first.
+func TestCatalog_ConcurrentWorkersDeterministicOutput(t *testing.T) {
+	newCatalogers := func(delays [3]time.Duration) []pkg.Cataloger {
+		return []pkg.Cataloger{
+			fakeCataloger{name: "c-zeta", pkgs: []pkg.Package{{Name: "zeta", Version: "1.0", Type: pkg.Type("fake-type")}}, delay: delays[0]},
+			fakeCataloger{name: "c-alpha", pkgs: []pkg.Package{{Name: "alpha", Version: "1.0", Type: pkg.Type("fake-type")}}, delay: delays[1]},
+			fakeCataloger{name: "c-mike", pkgs: []pkg.Package{{Name: "mike", Version: "1.0", Type: pkg.Type("fake-type")}}, delay: delays[2]},
+		}
+	}
+
+	run := func(delays [3]time.Duration) []string {
+		catalog, _, err := Catalog(context.Background(), nil, nil, CatalogConfig{Parallelism: 2}, newCatalogers(delays)...)
+		if err != nil {
+			t.Fatalf("unexpected error from Catalog: %v", err)
+		}
+		return packageNames(catalog)
+	}
+
+	// first run: the cataloger that appears first finishes last
+	forward := run([3]time.Duration{30 * time.Millisecond, 5 * time.Millisecond, 15 * time.Millisecond})
+	// second run: completion order is reversed relative to the first run
+	reversed := run([3]time.Duration{5 * time.Millisecond, 30 * time.Millisecond, 15 * time.Millisecond})
+
+	if len(forward) != len(reversed) {
+		t.Fatalf("expected equal-length results, got %v and %v", forward, reversed)
+	}
+	for i := range forward {
+		if forward[i] != reversed[i] {
+			t.Fatalf("catalog contents depend on worker completion order: %v vs %v", forward, reversed)
+		}
+	}
+}
+
+// TestCatalog_ContextCancellation asserts that a context cancelled before a scan starts is surfaced as an error
+// instead of silently returning as if cataloging had completed normally.
+func TestCatalog_ContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	catalogers := []pkg.Cataloger{
+		fakeCataloger{name: "c-blocked", block: true},
+		fakeCataloger{name: "c-also-blocked", block: true},
+	}
+
+	catalog, relationships, err := Catalog(ctx, nil, nil, CatalogConfig{Parallelism: 2}, catalogers...)
+	if err == nil {
+		t.Fatalf("expected an error for a cancelled context, got catalog=%v relationships=%v", catalog, relationships)
+	}
+}
+
+// TestCatalog_ContextCancellationMidScan cancels the context only after one cataloger has already produced
+// packages, asserting that in-flight cancellation still surfaces as an error rather than a partial success.
+func TestCatalog_ContextCancellationMidScan(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	catalogers := []pkg.Cataloger{
+		fakeCataloger{name: "c-fast", pkgs: []pkg.Package{{Name: "fast", Version: "1.0", Type: pkg.Type("fake-type")}}},
+		fakeCataloger{name: "c-blocked", block: true},
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	_, _, err := Catalog(ctx, nil, nil, CatalogConfig{Parallelism: 1}, catalogers...)
+	if err == nil {
+		t.Fatalf("expected an error once the context was cancelled mid-scan")
+	}
+}
+
+// TestParallelismFromContext asserts the WithParallelism/ParallelismFromContext round trip, and that an untouched
+// context reports no value present rather than a zero value.
+func TestParallelismFromContext(t *testing.T) {
+	if n, ok := ParallelismFromContext(context.Background()); ok {
+		t.Fatalf("expected no parallelism present on a bare context, got %d", n)
+	}
+
+	ctx := WithParallelism(context.Background(), 4)
+	n, ok := ParallelismFromContext(ctx)
+	if !ok {
+		t.Fatalf("expected a parallelism value set by WithParallelism to be present")
+	}
+	if n != 4 {
+		t.Fatalf("expected parallelism 4, got %d", n)
+	}
+}
+
+// TestCatalog_ParallelismFromContext runs Catalog with a context-supplied parallelism and an unset
+// CatalogConfig.Parallelism, and asserts that every cataloger's packages are still returned. It does not check how
+// many catalogers actually ran concurrently.
+func TestCatalog_ParallelismFromContext(t *testing.T) {
+	catalogers := []pkg.Cataloger{
+		fakeCataloger{name: "c-one", pkgs: []pkg.Package{{Name: "one", Version: "1.0", Type: pkg.Type("fake-type")}}},
+		fakeCataloger{name: "c-two", pkgs: []pkg.Package{{Name: "two", Version: "1.0", Type: pkg.Type("fake-type")}}},
+	}
+
+	ctx := WithParallelism(context.Background(), 2)
+	catalog, _, err := Catalog(ctx, nil, nil, CatalogConfig{}, catalogers...)
+	if err != nil {
+		t.Fatalf("unexpected error from Catalog: %v", err)
+	}
+
+	names := packageNames(catalog)
+	if len(names) != 2 {
+		t.Fatalf("expected 2 packages, got %v", names)
+	}
+}
+
+// TestCatalog_OwnedFileResolutionErrorFailsFingerprint asserts that a resolver error while resolving a package's
+// owned files fails that cataloger's result instead of silently computing a fingerprint from a degraded field set.
+func TestCatalog_OwnedFileResolutionErrorFailsFingerprint(t *testing.T) {
+	catalogers := []pkg.Cataloger{
+		fakeCataloger{name: "c-owner", pkgs: []pkg.Package{{
+			Name:     "owned",
+			Version:  "1.0",
+			Type:     pkg.Type("fake-type"),
+			Metadata: erroringFileOwner{},
+		}}},
+	}
+
+	_, _, err := Catalog(context.Background(), erroringResolver{}, nil, CatalogConfig{}, catalogers...)
+	if err == nil {
+		t.Fatalf("expected an error when owned-file resolution fails, got none")
+	}
+}
+
+// erroringFileOwner implements pkg.FileOwner, claiming ownership of a single path so that resolveOwnedFiles queries
+// the resolver below.
+type erroringFileOwner struct{}
+
+func (erroringFileOwner) OwnedFiles() []string {
+	return []string{"/fake/path"}
+}
+
+// erroringResolver implements source.FileResolver, failing every FilesByPath call to simulate a transient resolver
+// I/O failure.
+type erroringResolver struct {
+	source.FileResolver
+}
+
+func (erroringResolver) FilesByPath(_ ...string) ([]source.Location, error) {
+	return nil, fmt.Errorf("simulated resolver failure")
+}
@@ -0,0 +1,36 @@
+package packages
+
+import "sort"
+
+// DiffFingerprints compares two sets of package fingerprints, each keyed by a stable package identifier (e.g.
+// pkg.Package.ID()), and returns the keys present in either set whose fingerprint differs: a key missing from
+// current that was present in prior, a key present in current that wasn't in prior, or a key present in both with a
+// different fingerprint value. The returned keys are sorted for deterministic output.
+//
+// This is the stable building block for a "syft verify" drift-detection mode that diffs the fingerprints of two
+// SBOMs and reports real content changes apart from cosmetic reordering. Loading fingerprints out of an SBOM
+// document and selecting the right catalogers to regenerate them for comparison are deliberately left out of this
+// package: both require an SBOM JSON schema and a cataloger-selection surface that this snapshot doesn't contain.
+func DiffFingerprints(prior, current map[string]string) []string {
+	changed := make(map[string]struct{})
+
+	for key, priorFingerprint := range prior {
+		if currentFingerprint, ok := current[key]; !ok || currentFingerprint != priorFingerprint {
+			changed[key] = struct{}{}
+		}
+	}
+
+	for key, currentFingerprint := range current {
+		if priorFingerprint, ok := prior[key]; !ok || priorFingerprint != currentFingerprint {
+			changed[key] = struct{}{}
+		}
+	}
+
+	keys := make([]string, 0, len(changed))
+	for key := range changed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
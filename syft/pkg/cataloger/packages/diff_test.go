@@ -0,0 +1,55 @@
+package packages
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffFingerprints(t *testing.T) {
+	tests := []struct {
+		name    string
+		prior   map[string]string
+		current map[string]string
+		want    []string
+	}{
+		{
+			name:    "identical",
+			prior:   map[string]string{"a": "1", "b": "2"},
+			current: map[string]string{"a": "1", "b": "2"},
+			want:    []string{},
+		},
+		{
+			name:    "changed",
+			prior:   map[string]string{"a": "1", "b": "2"},
+			current: map[string]string{"a": "1", "b": "3"},
+			want:    []string{"b"},
+		},
+		{
+			name:    "added",
+			prior:   map[string]string{"a": "1"},
+			current: map[string]string{"a": "1", "b": "2"},
+			want:    []string{"b"},
+		},
+		{
+			name:    "removed",
+			prior:   map[string]string{"a": "1", "b": "2"},
+			current: map[string]string{"a": "1"},
+			want:    []string{"b"},
+		},
+		{
+			name:    "multiple differences, sorted",
+			prior:   map[string]string{"z": "1", "a": "1"},
+			current: map[string]string{"z": "2", "m": "1"},
+			want:    []string{"a", "m", "z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffFingerprints(tt.prior, tt.current)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("DiffFingerprints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultCacheDir returns the default location for syft's content-addressable blob cache:
+// $XDG_CACHE_HOME/syft/blobs, falling back to the current user's cache directory (os.UserCacheDir, which itself
+// honors $HOME) when XDG_CACHE_HOME is unset. This never resolves to a root-owned path, so rootless invocations
+// get a working per-user cache without any special-casing.
+func DefaultCacheDir() string {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "syft", "blobs")
+	}
+
+	base, err := os.UserCacheDir()
+	if err != nil {
+		// os.UserCacheDir only fails when neither XDG_CACHE_HOME nor HOME can be determined; fall back to a
+		// relative directory rather than erroring, consistent with never requiring root.
+		base = ".cache"
+	}
+
+	return filepath.Join(base, "syft", "blobs")
+}
+
+// ErrInvalidDigest is returned when a digest does not have the "algo:hex" form OCI digests require.
+var ErrInvalidDigest = errors.New("invalid digest")
+
+// BlobCache is a content-addressable store for image layers and manifests, keyed by OCI digest (e.g.
+// "sha256:deadbeef..."). Writes are atomic: a new blob is written to a temp file in the cache directory and
+// renamed into place, so a reader never observes a partially-written blob and a crash mid-write never corrupts an
+// existing entry.
+type BlobCache struct {
+	dir string
+}
+
+// NewBlobCache returns a BlobCache rooted at dir, creating dir (and any missing parents) if necessary.
+func NewBlobCache(dir string) (*BlobCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create cache directory %q: %w", dir, err)
+	}
+	return &BlobCache{dir: dir}, nil
+}
+
+func blobPath(dir, digest string) (string, error) {
+	algo, hex, ok := strings.Cut(digest, ":")
+	if !ok || algo == "" || hex == "" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidDigest, digest)
+	}
+	return filepath.Join(dir, algo, hex), nil
+}
+
+// Has reports whether digest is already present in the cache.
+func (c *BlobCache) Has(digest string) bool {
+	path, err := blobPath(c.dir, digest)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// Open returns a reader for the cached blob matching digest. Callers that need to distinguish a cache miss from a
+// read error should check errors.Is(err, os.ErrNotExist).
+func (c *BlobCache) Open(digest string) (io.ReadCloser, error) {
+	path, err := blobPath(c.dir, digest)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// Put stores the contents of r under digest, writing atomically: the content is written to a temp file alongside
+// the final location and renamed into place, so concurrent readers and a crash mid-write never see a partial blob.
+func (c *BlobCache) Put(digest string, r io.Reader) error {
+	path, err := blobPath(c.dir, digest)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("unable to create cache directory for %q: %w", digest, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("unable to create temp file for %q: %w", digest, err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to write blob %q: %w", digest, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to finalize blob %q: %w", digest, err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("unable to move blob %q into place: %w", digest, err)
+	}
+
+	return nil
+}
+
+// CachePrunePolicy controls which blobs PruneCache removes. A zero-value policy removes nothing.
+type CachePrunePolicy struct {
+	// MaxAge removes blobs whose content hasn't been modified in longer than this. Zero disables age-based pruning.
+	MaxAge time.Duration
+	// MaxSizeBytes evicts the oldest blobs (by modification time) until the cache is at or under this size. Zero
+	// disables size-based pruning.
+	MaxSizeBytes int64
+}
+
+type cacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// PruneCache walks dir applying policy: it first removes blobs older than policy.MaxAge, then, if the cache still
+// exceeds policy.MaxSizeBytes, evicts the oldest remaining blobs (by modification time) until it fits. It returns
+// the number of blobs removed and the total bytes freed.
+func PruneCache(ctx context.Context, dir string, policy CachePrunePolicy) (removed int, freedBytes int64, err error) {
+	var entries []cacheEntry
+	var totalSize int64
+
+	walkErr := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("unable to stat %q: %w", path, err)
+		}
+
+		entries = append(entries, cacheEntry{path: path, size: info.Size(), modTime: info.ModTime()})
+		totalSize += info.Size()
+		return nil
+	})
+	if walkErr != nil {
+		return 0, 0, fmt.Errorf("unable to walk cache directory %q: %w", dir, walkErr)
+	}
+
+	remove := func(e cacheEntry) error {
+		if err := os.Remove(e.path); err != nil {
+			return fmt.Errorf("unable to remove cache entry %q: %w", e.path, err)
+		}
+		removed++
+		freedBytes += e.size
+		totalSize -= e.size
+		return nil
+	}
+
+	kept := entries
+	if policy.MaxAge > 0 {
+		kept = nil
+		cutoff := time.Now().Add(-policy.MaxAge)
+		for _, e := range entries {
+			if e.modTime.Before(cutoff) {
+				if err := remove(e); err != nil {
+					return removed, freedBytes, err
+				}
+				continue
+			}
+			kept = append(kept, e)
+		}
+	}
+
+	if policy.MaxSizeBytes > 0 && totalSize > policy.MaxSizeBytes {
+		sort.Slice(kept, func(i, j int) bool { return kept[i].modTime.Before(kept[j].modTime) })
+		for _, e := range kept {
+			if totalSize <= policy.MaxSizeBytes {
+				break
+			}
+			if err := remove(e); err != nil {
+				return removed, freedBytes, err
+			}
+		}
+	}
+
+	return removed, freedBytes, nil
+}
@@ -0,0 +1,159 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBlobCache_PutOpenHas(t *testing.T) {
+	dir := t.TempDir()
+
+	cache, err := NewBlobCache(dir)
+	if err != nil {
+		t.Fatalf("NewBlobCache: %v", err)
+	}
+
+	digest := "sha256:deadbeef"
+	if cache.Has(digest) {
+		t.Fatalf("expected cache miss before Put")
+	}
+
+	if err := cache.Put(digest, bytes.NewReader([]byte("hello"))); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if !cache.Has(digest) {
+		t.Fatalf("expected cache hit after Put")
+	}
+
+	r, err := cache.Open(digest)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer r.Close()
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != "hello" {
+		t.Fatalf("expected %q, got %q", "hello", string(got))
+	}
+}
+
+func TestBlobCache_PutLeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	cache, err := NewBlobCache(dir)
+	if err != nil {
+		t.Fatalf("NewBlobCache: %v", err)
+	}
+
+	if err := cache.Put("sha256:abc123", bytes.NewReader([]byte("content"))); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && filepath.Base(path)[0] == '.' {
+			t.Errorf("found leftover temp file: %s", path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkDir: %v", err)
+	}
+}
+
+func TestBlobCache_InvalidDigest(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewBlobCache(dir)
+	if err != nil {
+		t.Fatalf("NewBlobCache: %v", err)
+	}
+
+	if err := cache.Put("not-a-digest", bytes.NewReader(nil)); err == nil {
+		t.Fatalf("expected an error for a malformed digest")
+	}
+}
+
+func TestPruneCache_MaxAge(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewBlobCache(dir)
+	if err != nil {
+		t.Fatalf("NewBlobCache: %v", err)
+	}
+
+	if err := cache.Put("sha256:old", bytes.NewReader([]byte("old"))); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	oldPath, _ := blobPath(dir, "sha256:old")
+	oldTime := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(oldPath, oldTime, oldTime); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	if err := cache.Put("sha256:fresh", bytes.NewReader([]byte("fresh"))); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	removed, freed, err := PruneCache(context.Background(), dir, CachePrunePolicy{MaxAge: 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("PruneCache: %v", err)
+	}
+	if removed != 1 {
+		t.Fatalf("expected 1 removed entry, got %d (freed %d bytes)", removed, freed)
+	}
+
+	if cache.Has("sha256:old") {
+		t.Fatalf("expected the old blob to have been pruned")
+	}
+	if !cache.Has("sha256:fresh") {
+		t.Fatalf("expected the fresh blob to survive pruning")
+	}
+}
+
+func TestPruneCache_MaxSize(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewBlobCache(dir)
+	if err != nil {
+		t.Fatalf("NewBlobCache: %v", err)
+	}
+
+	write := func(digest, content string, age time.Duration) {
+		if err := cache.Put(digest, bytes.NewReader([]byte(content))); err != nil {
+			t.Fatalf("Put(%s): %v", digest, err)
+		}
+		path, _ := blobPath(dir, digest)
+		modTime := time.Now().Add(-age)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Chtimes(%s): %v", digest, err)
+		}
+	}
+
+	// three 10-byte blobs, oldest to newest
+	write("sha256:a", "0123456789", 3*time.Hour)
+	write("sha256:b", "0123456789", 2*time.Hour)
+	write("sha256:c", "0123456789", 1*time.Hour)
+
+	removed, freed, err := PruneCache(context.Background(), dir, CachePrunePolicy{MaxSizeBytes: 15})
+	if err != nil {
+		t.Fatalf("PruneCache: %v", err)
+	}
+	if removed != 2 {
+		t.Fatalf("expected 2 entries evicted to get under the size cap, got %d (freed %d bytes)", removed, freed)
+	}
+	if cache.Has("sha256:a") || cache.Has("sha256:b") {
+		t.Fatalf("expected the two oldest blobs to have been evicted")
+	}
+	if !cache.Has("sha256:c") {
+		t.Fatalf("expected the newest blob to survive eviction")
+	}
+}